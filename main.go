@@ -18,14 +18,19 @@ conclusion: use buffered io
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"sort"
 	"strconv"
 
 	"go/build"
+	"go/format"
 	"path/filepath"
 	"strings"
 	"unicode"
@@ -245,17 +250,59 @@ func makeVarNameFromFileName(path, prefix string) (string, error) {
 	return makeVarName(filepath.Base(path), prefix)
 }
 
+// makeVarNameFromPath derives an identifier from a file's full relative path rather than just
+// its base name, so that files sharing a base name in different directories still get unique
+// identifiers, e.g. "assets/index.html" becomes "assetsIndexHtml". The extension is kept as a
+// word rather than trimmed, so "assets/index.html" and "assets/index.json" don't collide on
+// the same generated name.
+func makeVarNameFromPath(path, prefix string) (string, error) {
+	clean := strings.ReplaceAll(filepath.ToSlash(path), "/", "-")
+	return makeVarName(clean, prefix)
+}
+
+// errConstEmbed is returned when embed mode is combined with -const, since a
+// //go:embed directive may only annotate a single package-level var.
+var errConstEmbed = errors.New("bundle: -embed cannot be combined with -const, //go:embed only applies to vars")
+
+// errCompressIncompatible is returned when -compress is combined with -embed or -fs, since
+// compression only rewrites the plain quoted-string var/const path.
+var errCompressIncompatible = errors.New("bundle: -compress is only supported in the default var/const mode, not with -embed or -fs")
+
+// errEmbedNeedsOutFile is returned when -embed is used without -out, since the generated
+// //go:embed directives are only valid relative to a known output file's directory.
+var errEmbedNeedsOutFile = errors.New("bundle: -embed requires -out, //go:embed paths must be relative to a known output file")
+
 // A Bundler holds the settings for the bundling process.
 type Bundler struct {
 	outFile     string
 	pkgName     string
 	prefix      string
 	decl        string
+	useEmbed    bool
+	useCompress bool
+	fsName      string
+	include     []string
+	exclude     []string
+	strip       string
 	makeVarName func(string, string) (string, error)
 }
 
-// NewBundler initialises a new Bundler with the given settings.
-func NewBundler(outFile string, pkgName string, prefix string, useConst bool, varNameFunc func(string, string) (string, error)) *Bundler {
+// bundleFile pairs a file's path on disk with the key recorded for it in generated code
+// (a map key, an identifier source, or an embed path), which differs from path once -strip
+// has trimmed a leading directory prefix.
+type bundleFile struct {
+	path string
+	key  string
+}
+
+// NewBundler initialises a new Bundler with the given settings. If fsName is non-empty, the
+// bundler ignores useConst and generates an io/fs.FS implementation named fsName instead of
+// one variable per file. include and exclude are glob patterns used to filter files found by
+// walking directory arguments or expanding doublestar-style globs; strip is a path prefix
+// trimmed from each file's path before it is used as a map key or identifier source. useCompress
+// gzips each file that shrinks under compression and generates lazy decompression accessors;
+// it is only valid together with the default var/const mode.
+func NewBundler(outFile string, pkgName string, prefix string, useConst bool, useEmbed bool, fsName string, include []string, exclude []string, strip string, useCompress bool, varNameFunc func(string, string) (string, error)) *Bundler {
 	if varNameFunc == nil {
 		varNameFunc = makeVarNameFromBaseName
 	}
@@ -263,6 +310,12 @@ func NewBundler(outFile string, pkgName string, prefix string, useConst bool, va
 		outFile:     outFile,
 		prefix:      prefix,
 		pkgName:     pkgName,
+		useEmbed:    useEmbed,
+		useCompress: useCompress,
+		fsName:      fsName,
+		include:     include,
+		exclude:     exclude,
+		strip:       strip,
 		makeVarName: varNameFunc,
 		decl: func() string {
 			if useConst {
@@ -273,13 +326,398 @@ func NewBundler(outFile string, pkgName string, prefix string, useConst bool, va
 	}
 }
 
-// ProcessFiles does the actual work by including each of the provided files into the output file.
-func (bundler *Bundler) ProcessFiles(files ...string) error {
-	var (
-		out *os.File
-		err error
-	)
+// ProcessFiles does the actual work by including each of the provided files into the output
+// file. Each argument may be a plain file path, a directory (walked recursively), or a
+// doublestar-style glob pattern such as "assets/**/*.html".
+func (bundler *Bundler) ProcessFiles(args ...string) error {
+	if bundler.useEmbed && bundler.decl == "const" {
+		return errConstEmbed
+	}
+	if bundler.useEmbed && bundler.outFile == "" {
+		return errEmbedNeedsOutFile
+	}
+	if bundler.useCompress && (bundler.useEmbed || bundler.fsName != "") {
+		return errCompressIncompatible
+	}
+
+	files, err := bundler.resolveFiles(args)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	// write header
+	if bundler.pkgName != "" {
+		writeHeaderWithPackage(&buf, bundler.pkgName)
+	} else {
+		err = writeHeader(&buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case bundler.fsName != "" && bundler.useEmbed:
+		if err = bundler.processFilesFSEmbed(&buf, files); err != nil {
+			return err
+		}
+	case bundler.fsName != "":
+		if err = bundler.processFilesFS(&buf, files); err != nil {
+			return err
+		}
+	case bundler.useEmbed:
+		fmt.Fprintln(&buf, `import _ "embed"`)
+		fmt.Fprintln(&buf, "")
+		if err = bundler.processFilesEmbed(&buf, files); err != nil {
+			return err
+		}
+	default:
+		if err = bundler.processFilesQuoted(&buf, files); err != nil {
+			return err
+		}
+	}
+
+	return bundler.writeFormatted(buf.Bytes())
+}
+
+// quotedFile holds the per-file decision made by processFilesQuoted: either the file is
+// gzip-compressed (compressed is non-nil) or it falls back to the plain quoted-string form.
+type quotedFile struct {
+	bf         bundleFile
+	varName    string
+	compressed []byte
+	origSize   int
+}
+
+// processFilesQuoted emits the default var/const block, one declaration per file. When the
+// bundler's useCompress is set, files that shrink under gzip are stored compressed along with
+// a lazily-decompressing accessor; Go requires that decision to be made before the output is
+// written, since the extra imports it needs must precede the var/const block rather than follow it.
+func (bundler *Bundler) processFilesQuoted(buf *bytes.Buffer, files []bundleFile) error {
+	quoted := make([]quotedFile, len(files))
+	anyCompressed := false
+
+	for i, bf := range files {
+		if Verbose {
+			fmt.Fprintln(os.Stderr, "processing file:", bf.path)
+		}
+
+		varName, err := bundler.makeVarName(bf.key, bundler.prefix)
+		if err != nil {
+			return err
+		}
+
+		qf := quotedFile{bf: bf, varName: varName}
+
+		if bundler.useCompress {
+			compressed, origSize, ok, err := compressFile(bf.path)
+			if err != nil {
+				return err
+			}
+			if ok {
+				qf.compressed = compressed
+				qf.origSize = origSize
+				anyCompressed = true
+			}
+		}
+
+		quoted[i] = qf
+	}
+
+	if anyCompressed {
+		fmt.Fprintln(buf, "import (")
+		fmt.Fprintln(buf, `	"compress/gzip"`)
+		fmt.Fprintln(buf, `	"io"`)
+		fmt.Fprintln(buf, `	"strings"`)
+		fmt.Fprintln(buf, `	"sync"`)
+		fmt.Fprintln(buf, ")")
+		fmt.Fprintln(buf, "")
+	}
+
+	fmt.Fprintf(buf, "// These %ss are included from files by go generate.\n", bundler.decl)
+	fmt.Fprintf(buf, "%s (", bundler.decl)
+
+	for _, qf := range quoted {
+		if qf.compressed != nil {
+			fmt.Fprintf(buf, "\n\t// file: %s (%d bytes uncompressed, gzip-compressed below)\n", qf.bf.path, qf.origSize)
+			fmt.Fprintf(buf, "\t%s = %s\n", qf.varName, strconv.Quote(string(qf.compressed)))
+			continue
+		}
+
+		fmt.Fprintf(buf, "\n\t// file: %s\n", qf.bf.path)
+		fmt.Fprintf(buf, "\t%s = ", qf.varName)
+
+		if err := quoteFile(buf, qf.bf.path); err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	fmt.Fprintf(buf, ")\n")
+
+	if anyCompressed {
+		fmt.Fprintln(buf, "")
+		fmt.Fprint(buf, mustGunzipHelper)
+		for _, qf := range quoted {
+			if qf.compressed == nil {
+				continue
+			}
+			fmt.Fprintf(buf, compressedAccessorTemplate, qf.varName, qf.varName, qf.varName, qf.varName, qf.varName, qf.varName, qf.varName, qf.varName, qf.varName)
+		}
+	}
+
+	return nil
+}
+
+// compressFile gzips the named file's contents. ok is false when the compressed form is not
+// smaller than the original, in which case the caller should fall back to storing it uncompressed.
+func compressFile(fn string) (compressed []byte, origSize int, ok bool, err error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(data); err != nil {
+		return nil, 0, false, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, 0, false, err
+	}
+
+	if buf.Len() >= len(data) {
+		return nil, len(data), false, nil
+	}
+
+	return buf.Bytes(), len(data), true, nil
+}
+
+// mustGunzipHelper is emitted once per file when any bundled file is gzip-compressed.
+const mustGunzipHelper = `func mustGunzip(s string) []byte {
+	r, err := gzip.NewReader(strings.NewReader(s))
+	if err != nil {
+		panic("bundle: invalid gzip data: " + err.Error())
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		panic("bundle: invalid gzip data: " + err.Error())
+	}
+
+	return data
+}
+`
+
+// compressedAccessorTemplate generates a pair of lazily-decompressing accessors for a
+// gzip-compressed var, caching the result with sync.Once so repeated calls are cheap.
+const compressedAccessorTemplate = `
+var %sOnce sync.Once
+var %sData []byte
+
+func %sBytes() []byte {
+	%sOnce.Do(func() { %sData = mustGunzip(%s) })
+	return %sData
+}
+
+func %sString() string {
+	return string(%sBytes())
+}
+`
+
+// resolveFiles expands args into a flat, sorted list of bundleFiles. A directory argument is
+// walked recursively; an argument containing glob metacharacters is expanded with doublestar
+// semantics ("**" matches zero or more path segments); anything else is treated as a plain
+// file path. The -include/-exclude filters and the -strip prefix are then applied.
+func (bundler *Bundler) resolveFiles(args []string) ([]bundleFile, error) {
+	var paths []string
+	seen := make(map[string]bool)
+
+	add := func(p string) error {
+		if seen[p] || !matchFilters(p, bundler.include, bundler.exclude) {
+			return nil
+		}
+		seen[p] = true
+		paths = append(paths, p)
+		return nil
+	}
+
+	for _, arg := range args {
+		if isGlobPattern(arg) {
+			matches, err := globWalk(arg)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range matches {
+				if err := add(m); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fi.IsDir() {
+			if err := add(arg); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(arg, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			return add(p)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(paths)
+
+	files := make([]bundleFile, len(paths))
+	for i, p := range paths {
+		files[i] = bundleFile{path: p, key: bundler.stripKey(p)}
+	}
+
+	return files, nil
+}
+
+// stripKey returns the path to record for p in generated code, with the -strip prefix (if
+// any) trimmed from the front.
+func (bundler *Bundler) stripKey(p string) string {
+	key := filepath.ToSlash(p)
+	if bundler.strip == "" {
+		return key
+	}
+
+	key = strings.TrimPrefix(key, filepath.ToSlash(bundler.strip))
+	return strings.TrimPrefix(key, "/")
+}
+
+// isGlobPattern reports whether s contains any glob metacharacters.
+func isGlobPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// globWalk expands a doublestar-style glob pattern by walking the non-glob directory prefix
+// of pattern and matching each file's relative path against the remaining pattern segments.
+func globWalk(pattern string) ([]string, error) {
+	segs := strings.Split(filepath.ToSlash(pattern), "/")
+
+	i := 0
+	for ; i < len(segs); i++ {
+		if isGlobPattern(segs[i]) {
+			break
+		}
+	}
+
+	base := "."
+	if i > 0 {
+		base = filepath.Join(segs[:i]...)
+	}
+	patSegs := segs[i:]
+
+	var matches []string
+	err := filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+
+		if matchSegments(patSegs, strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// matchSegments matches path segments against pattern segments, where "**" matches zero or
+// more segments and every other segment is matched with filepath.Match.
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		if matchSegments(pattern[1:], name) {
+			return true
+		}
+		if len(name) == 0 {
+			return false
+		}
+		return matchSegments(pattern, name[1:])
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	if ok, err := filepath.Match(pattern[0], name[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// matchFilters reports whether p passes the given -include/-exclude glob patterns, matched
+// against both its base name and its full slash-separated path.
+func matchFilters(p string, include, exclude []string) bool {
+	if len(include) > 0 && !matchAny(p, include) {
+		return false
+	}
+	return !matchAny(p, exclude)
+}
 
+func matchAny(p string, patterns []string) bool {
+	base := filepath.Base(p)
+	slash := filepath.ToSlash(p)
+	for _, pat := range patterns {
+		if ok, _ := filepath.Match(pat, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pat, slash); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFormatted runs src through go/format.Source and writes the result to the bundler's
+// output (a file, or stdout if none was given). If formatting fails, the raw, unformatted
+// source is preserved next to outFile with an ".unformatted" suffix so the generator bug
+// that produced it can be diagnosed, and a wrapped error is returned.
+func (bundler *Bundler) writeFormatted(src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		if bundler.outFile == "" {
+			return fmt.Errorf("format generated code: %w", err)
+		}
+
+		rawFile := bundler.outFile + ".unformatted"
+		if writeErr := os.WriteFile(rawFile, src, 0644); writeErr != nil {
+			return fmt.Errorf("format generated code: %w (also failed to write %s: %v)", err, rawFile, writeErr)
+		}
+		return fmt.Errorf("format generated code: %w (raw output written to %s)", err, rawFile)
+	}
+
+	var out *os.File
 	if bundler.outFile != "" {
 		out, err = os.Create(bundler.outFile)
 		if err != nil {
@@ -295,61 +733,393 @@ func (bundler *Bundler) ProcessFiles(files ...string) error {
 	bw := bufio.NewWriter(out)
 	defer bw.Flush()
 
-	// write header
-	if bundler.pkgName != "" {
-		writeHeaderWithPackage(bw, bundler.pkgName)
-	} else {
-		err = writeHeader(bw)
+	_, err = bw.Write(formatted)
+	return err
+}
+
+// processFilesEmbed writes one //go:embed-annotated var declaration per file, since the
+// directive must immediately precede a single top-level var and can't share a var( ) block.
+func (bundler *Bundler) processFilesEmbed(bw io.Writer, files []bundleFile) error {
+	fmt.Fprintf(bw, "// These %ss are included from files by go generate using //go:embed.\n", bundler.decl)
+
+	for _, bf := range files {
+		if Verbose {
+			fmt.Fprintln(os.Stderr, "processing file:", bf.path)
+		}
+
+		varName, err := bundler.makeVarName(bf.key, bundler.prefix)
 		if err != nil {
 			return err
 		}
+
+		embedPath, err := bundler.embedPathFor(bf.path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(bw, "\n//go:embed %s\n", embedPath)
+		fmt.Fprintf(bw, "%s %s []byte\n", bundler.decl, varName)
+	}
+
+	return nil
+}
+
+// embedCopyDir is the directory created next to the output file to hold copies of embedded
+// files that live outside it, since a //go:embed pattern may not reference "..". Copies are
+// nested under a sanitized form of the source's own path rather than just its base name, so
+// files with the same base name in different source directories get distinct destinations
+// instead of silently colliding or clobbering one another.
+const embedCopyDir = "bundle_embed"
+
+// embedPathFor returns the path to use in a //go:embed directive for fn, relative to the
+// directory the output file is written to. A //go:embed pattern may not contain "..", so
+// files living outside that directory are copied into embedCopyDir alongside the output file first.
+func (bundler *Bundler) embedPathFor(fn string) (string, error) {
+	outDir := "."
+	if bundler.outFile != "" {
+		outDir = filepath.Dir(bundler.outFile)
+	}
+
+	rel, err := filepath.Rel(outDir, fn)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return copyFileIntoEmbedDir(outDir, fn)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// copyFileIntoEmbedDir copies src into outDir/embedCopyDir, nested by a sanitized form of
+// src's own path so that it can be referenced by a //go:embed directive relative to a
+// generated file living in outDir without colliding with any other copied file.
+func copyFileIntoEmbedDir(outDir, src string) (string, error) {
+	relDst := filepath.Join(embedCopyDir, sanitizeEmbedCopyPath(src))
+	dst := filepath.Join(outDir, relDst)
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(relDst), nil
+}
+
+// sanitizeEmbedCopyPath turns src into a relative path safe to nest under embedCopyDir: a
+// leading volume name or root is dropped, "." segments are removed, and ".." segments (which
+// would otherwise let the copy escape back out of embedCopyDir) are replaced with a literal "up".
+func sanitizeEmbedCopyPath(src string) string {
+	vol := filepath.VolumeName(src)
+	src = strings.TrimPrefix(src[len(vol):], string(filepath.Separator))
+
+	segs := strings.Split(filepath.ToSlash(filepath.Clean(src)), "/")
+	clean := segs[:0]
+	for _, s := range segs {
+		switch s {
+		case "", ".":
+			continue
+		case "..":
+			clean = append(clean, "up")
+		default:
+			clean = append(clean, s)
+		}
 	}
+	return filepath.Join(clean...)
+}
 
-	fmt.Fprintf(bw, "// These %ss are included from files by go generate.\n", bundler.decl)
-	fmt.Fprintf(bw, "%s (", bundler.decl)
+// processFilesFSEmbed generates a single var of type embed.FS backed by a //go:embed
+// directive listing every bundled file, satisfying io/fs.FS (and ReadDirFS/ReadFileFS)
+// with no hand-written glue code.
+func (bundler *Bundler) processFilesFSEmbed(buf *bytes.Buffer, files []bundleFile) error {
+	fsName, err := bundler.makeVarName(bundler.fsName, bundler.prefix)
+	if err != nil {
+		return err
+	}
 
-	for _, fn := range files {
+	paths := make([]string, 0, len(files))
+	for _, bf := range files {
 		if Verbose {
-			fmt.Fprintln(os.Stderr, "processing file:", fn)
+			fmt.Fprintln(os.Stderr, "processing file:", bf.path)
 		}
 
-		// get variable name
-		varName, err := bundler.makeVarName(fn, bundler.prefix)
+		embedPath, err := bundler.embedPathFor(bf.path)
 		if err != nil {
 			return err
 		}
-		//fmt.Fprintf(bw, "%s %s = ", bundler.decl, varName)
-		fmt.Fprintf(bw, "\n\t// file: %s\n", fn)
-		fmt.Fprintf(bw, "\t%s = ", varName)
+		paths = append(paths, embedPath)
+	}
 
-		err = quoteFile(bw, fn)
-		if err != nil {
+	fmt.Fprintln(buf, `import "embed"`)
+	fmt.Fprintln(buf, "")
+	fmt.Fprintf(buf, "// %s is a generated io/fs.FS over the files bundled by go generate.\n", fsName)
+	fmt.Fprintf(buf, "//go:embed %s\n", strings.Join(paths, " "))
+	fmt.Fprintf(buf, "var %s embed.FS\n", fsName)
+
+	return nil
+}
+
+// processFilesFS generates a type implementing io/fs.FS, fs.ReadFileFS and fs.ReadDirFS
+// over the given files, keyed by their original paths, backed by a map[string][]byte.
+func (bundler *Bundler) processFilesFS(buf *bytes.Buffer, files []bundleFile) error {
+	fsName, err := bundler.makeVarName(bundler.fsName, bundler.prefix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(buf, "import (")
+	fmt.Fprintln(buf, `"bytes"`)
+	fmt.Fprintln(buf, `"errors"`)
+	fmt.Fprintln(buf, `"io"`)
+	fmt.Fprintln(buf, `"io/fs"`)
+	fmt.Fprintln(buf, `"path"`)
+	fmt.Fprintln(buf, `"sort"`)
+	fmt.Fprintln(buf, `"strings"`)
+	fmt.Fprintln(buf, `"time"`)
+	fmt.Fprintln(buf, ")")
+	fmt.Fprintln(buf, "")
+
+	fmt.Fprintf(buf, "// %sFS backs %s, mapping bundled file paths to their contents.\n", fsName, fsName)
+	fmt.Fprintf(buf, "type %sFS map[string][]byte\n\n", fsName)
+
+	fmt.Fprintf(buf, "// %s is a generated io/fs.FS over the files bundled by go generate.\n", fsName)
+	fmt.Fprintf(buf, "var %s = %sFS{\n", fsName, fsName)
+
+	for _, bf := range files {
+		if Verbose {
+			fmt.Fprintln(os.Stderr, "processing file:", bf.path)
+		}
+
+		fmt.Fprintf(buf, "%s: []byte(", strconv.Quote(bf.key))
+		if err := quoteFile(buf, bf.path); err != nil {
 			return err
 		}
-		fmt.Fprintf(bw, "\n")
+		fmt.Fprint(buf, "),\n")
 	}
-	fmt.Fprintf(bw, ")\n")
+	fmt.Fprint(buf, "}\n\n")
+
+	fmt.Fprintf(buf, fsHelperTemplate, fsName)
 
 	return nil
 }
 
+// fsHelperTemplate is the io/fs.FS glue emitted once per -fs bundle; %[1]s is replaced
+// with the chosen FS name and reused as the prefix for its unexported helper types.
+const fsHelperTemplate = `
+func (f %[1]sFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if data, ok := f[name]; ok {
+		data = append([]byte(nil), data...)
+		info := %[1]sFileInfo{name: path.Base(name), size: int64(len(data))}
+		return &%[1]sFile{info: info, Reader: bytes.NewReader(data)}, nil
+	}
+
+	entries, ok := f.dirEntries(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	info := %[1]sFileInfo{name: path.Base(name), isDir: true}
+	return &%[1]sDir{info: info, entries: entries}, nil
+}
+
+func (f %[1]sFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+
+	data, ok := f[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrNotExist}
+	}
+
+	// return a copy: callers must be free to mutate the result without corrupting f's
+	// backing data, which fs.ReadFileFS and fstest.TestFS both require.
+	return append([]byte(nil), data...), nil
+}
+
+func (f %[1]sFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name != "." && !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entries, ok := f.dirEntries(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return entries, nil
+}
+
+// dirEntries reports the direct children of name the way ReadDir would, and whether name
+// names a directory at all: "." always does, as does any path with at least one file nested
+// under it. It backs both ReadDir and Open, so that Open(".") and Open of any other directory
+// path succeed instead of always reporting fs.ErrNotExist.
+func (f %[1]sFS) dirEntries(name string) ([]fs.DirEntry, bool) {
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+
+	isDir := name == "."
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for k, v := range f {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		isDir = true
+
+		rest := strings.TrimPrefix(k, prefix)
+		child, childIsDir := rest, false
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			child, childIsDir = rest[:i], true
+		}
+
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+
+		// a directory's "size" is meaningless here (v is an arbitrary file nested under
+		// it, not the directory itself), so report 0 to match the FileInfo Open returns
+		// for the same directory.
+		size := int64(0)
+		if !childIsDir {
+			size = int64(len(v))
+		}
+		entries = append(entries, %[1]sFileInfo{name: child, size: size, isDir: childIsDir})
+	}
+
+	if !isDir {
+		return nil, false
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, true
+}
+
+// %[1]sFile is an open bundled file, backed by a bytes.Reader over its contents.
+type %[1]sFile struct {
+	info %[1]sFileInfo
+	*bytes.Reader
+}
+
+func (f *%[1]sFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *%[1]sFile) Close() error               { return nil }
+
+// %[1]sDir is an open bundled directory, implementing fs.ReadDirFile.
+type %[1]sDir struct {
+	info    %[1]sFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *%[1]sDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *%[1]sDir) Close() error               { return nil }
+
+func (d *%[1]sDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *%[1]sDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		entries := d.entries[d.offset:]
+		d.offset = len(d.entries)
+		return entries, nil
+	}
+
+	if d.offset >= len(d.entries) {
+		return nil, io.EOF
+	}
+
+	end := d.offset + n
+	if end > len(d.entries) {
+		end = len(d.entries)
+	}
+	entries := d.entries[d.offset:end]
+	d.offset = end
+	return entries, nil
+}
+
+// %[1]sFileInfo implements both fs.FileInfo and fs.DirEntry for a bundled file or directory.
+type %[1]sFileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+func (fi %[1]sFileInfo) Name() string       { return fi.name }
+func (fi %[1]sFileInfo) Size() int64        { return fi.size }
+func (fi %[1]sFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi %[1]sFileInfo) IsDir() bool        { return fi.isDir }
+func (fi %[1]sFileInfo) Sys() interface{}   { return nil }
+
+func (fi %[1]sFileInfo) Mode() fs.FileMode {
+	if fi.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+func (fi %[1]sFileInfo) Type() fs.FileMode          { return fi.Mode().Type() }
+func (fi %[1]sFileInfo) Info() (fs.FileInfo, error) { return fi, nil }
+`
+
+// multiFlag accumulates repeated occurrences of a string flag, e.g. -include "*.html" -include "*.css".
+type multiFlag []string
+
+func (m *multiFlag) String() string { return strings.Join(*m, ",") }
+
+func (m *multiFlag) Set(v string) error {
+	*m = append(*m, v)
+	return nil
+}
+
 func main() {
 	var (
 		outFile  = flag.String("out", "", "`file` name to write generated code to (STDOUT if not provided)")
 		prefix   = flag.String("prefix", "", "prefix for generated variables")
 		useConst = flag.Bool("const", false, "use const instead of var")
+		useEmbed = flag.Bool("embed", false, "use //go:embed directives instead of quoted strings (requires -out, not compatible with -const)")
+		fsName   = flag.String("fs", "", "`name` of an io/fs.FS variable to generate over the bundled files, instead of one variable per file")
+		strip    = flag.String("strip", "", "`prefix` to strip from each file's path before it is used as a map key or identifier source")
+		compress = flag.Bool("compress", false, "gzip-compress files that shrink under compression and generate lazy decompression accessors (not compatible with -embed or -fs)")
+		keyName  = flag.String("keyname", "base", "`strategy` for turning a file's path into an identifier: base, filename, or path (use path to avoid collisions between files with the same base name)")
 		pkgName  = flag.String("pkg", "", "override package name of generated file")
 		verbose  = flag.Bool("v", false, "verbose; print name of files as they are processed")
+		include  multiFlag
+		exclude  multiFlag
 	)
+	flag.Var(&include, "include", "glob `pattern` a file must match to be bundled; may be repeated")
+	flag.Var(&exclude, "exclude", "glob `pattern` excluding matching files from being bundled; may be repeated")
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "usage: %s <file> <file>...\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "usage: %s <file|dir|glob> <file|dir|glob>...\n", os.Args[0])
 		flag.PrintDefaults()
 	}
 	flag.Parse()
 
 	Verbose = *verbose
 
-	bundler := NewBundler(*outFile, *pkgName, *prefix, *useConst, makeVarNameFromBaseName)
+	var varNameFunc func(string, string) (string, error)
+	switch *keyName {
+	case "path":
+		varNameFunc = makeVarNameFromPath
+	case "filename":
+		varNameFunc = makeVarNameFromFileName
+	default:
+		varNameFunc = makeVarNameFromBaseName
+	}
+
+	bundler := NewBundler(*outFile, *pkgName, *prefix, *useConst, *useEmbed, *fsName, include, exclude, *strip, *compress, varNameFunc)
 	err := bundler.ProcessFiles(flag.Args()...)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err)
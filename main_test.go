@@ -5,7 +5,10 @@ import (
 	"flag"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -27,11 +30,16 @@ func Map(arr []string, f func(string) string) []string {
 
 func TestBundler(t *testing.T) {
 	tests := []struct {
-		name     string
-		pkg      string
-		prefix   string
-		useConst bool
-		varFunc  func(string, string) (string, error)
+		name        string
+		pkg         string
+		prefix      string
+		useConst    bool
+		useEmbed    bool
+		fsName      string
+		strip       string
+		useCompress bool
+		args        []string // defaults to testFiles under testdata/ when nil
+		varFunc     func(string, string) (string, error)
 	}{
 		{
 			name:     "var",
@@ -47,6 +55,49 @@ func TestBundler(t *testing.T) {
 			useConst: true,
 			varFunc:  makeVarNameFromFileName,
 		},
+		{
+			name:     "embed",
+			pkg:      "",
+			prefix:   "gen",
+			useConst: false,
+			useEmbed: true,
+			varFunc:  makeVarNameFromBaseName,
+		},
+		{
+			name:    "fs",
+			pkg:     "",
+			prefix:  "",
+			fsName:  "Assets",
+			varFunc: makeVarNameFromBaseName,
+		},
+		{
+			name:    "nested",
+			pkg:     "",
+			prefix:  "",
+			fsName:  "Assets",
+			strip:   "testdata/assets",
+			args:    []string{"testdata/assets"},
+			varFunc: makeVarNameFromPath,
+		},
+		{
+			name:        "compress",
+			pkg:         "",
+			prefix:      "gen",
+			useCompress: true,
+			args:        []string{"testdata/compressible.txt", "testdata/helloworld.go"},
+			varFunc:     makeVarNameFromBaseName,
+		},
+		{
+			// regression test for makeVarNameFromPath: index.html and index.json share a
+			// directory and base name, so -keyname path must fold the extension into the
+			// identifier (assetsIndexHtml / assetsIndexJson) rather than collide.
+			name:    "pathkeys",
+			pkg:     "",
+			prefix:  "gen",
+			strip:   "testdata/",
+			args:    []string{"testdata/pathkeys/index.html", "testdata/pathkeys/index.json"},
+			varFunc: makeVarNameFromPath,
+		},
 	}
 
 	Verbose = testing.Verbose()
@@ -68,13 +119,16 @@ func TestBundler(t *testing.T) {
 			outFile := filepath.Join(tmpDir, test.name+"_out.go")
 			goldenFile := filepath.Join("testdata", test.name+"_golden.go")
 
-			bundler := NewBundler(outFile, test.pkg, test.prefix, test.useConst, test.varFunc)
-			err = bundler.ProcessFiles(Map(
-				testFiles,
-				func(fn string) string {
+			bundler := NewBundler(outFile, test.pkg, test.prefix, test.useConst, test.useEmbed, test.fsName, nil, nil, test.strip, test.useCompress, test.varFunc)
+
+			args := test.args
+			if args == nil {
+				args = Map(testFiles, func(fn string) string {
 					return filepath.Join("testdata", fn)
-				})...,
-			)
+				})
+			}
+
+			err = bundler.ProcessFiles(args...)
 			if err != nil {
 				t.Fatal("error generating file:", err)
 			}
@@ -99,3 +153,109 @@ func TestBundler(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveFiles checks that walking a directory and expanding a doublestar glob over the
+// same nested tree produce the same set of keys that fs.WalkDir would report for the
+// resulting io/fs.FS, after -strip trims the shared testdata/assets prefix.
+func TestResolveFiles(t *testing.T) {
+	want := []string{"css/style.css", "index.html", "js/app.js"}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "directory", args: []string{"testdata/assets"}},
+		{name: "doublestar glob", args: []string{"testdata/assets/**/*"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundler := NewBundler("", "", "", false, false, "", nil, nil, "testdata/assets", false, makeVarNameFromPath)
+
+			files, err := bundler.resolveFiles(test.args)
+			if err != nil {
+				t.Fatal("error resolving files:", err)
+			}
+
+			got := make([]string, len(files))
+			for i, bf := range files {
+				got[i] = bf.key
+			}
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("resolveFiles(%v) keys = %v, want %v", test.args, got, want)
+			}
+		})
+	}
+}
+
+// TestGeneratedFSConformance builds the FS generated for a nested testdata/ tree into a real,
+// standalone program and runs it with "go run", checking that fs.WalkDir and fstest.TestFS see
+// a complete and conformant io/fs.FS. A byte-for-byte comparison against the golden file alone
+// can't catch a broken Open("."): it has to actually be opened to notice.
+func TestGeneratedFSConformance(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available:", err)
+	}
+
+	dir := t.TempDir()
+
+	bundler := NewBundler(filepath.Join(dir, "assets.go"), "main", "", false, false, "Assets", nil, nil, "testdata/assets", false, makeVarNameFromPath)
+	if err := bundler.ProcessFiles("testdata/assets"); err != nil {
+		t.Fatal("error generating file:", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module fsconformance\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "harness.go"), []byte(fsConformanceHarness), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go run generated FS conformance harness: %v\n%s", err, out)
+	}
+}
+
+// fsConformanceHarness is compiled alongside a generated FS by TestGeneratedFSConformance. It
+// runs fstest.TestFS (the standard library's own FS conformance suite) and fs.WalkDir, both of
+// which require Open to handle directory paths, not just the file paths stored in the map.
+const fsConformanceHarness = `package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"testing/fstest"
+)
+
+func main() {
+	if err := fstest.TestFS(Assets, "index.html", "css/style.css", "js/app.js"); err != nil {
+		fmt.Fprintln(os.Stderr, "fstest.TestFS:", err)
+		os.Exit(1)
+	}
+
+	var walked []string
+	err := fs.WalkDir(Assets, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		walked = append(walked, path)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fs.WalkDir:", err)
+		os.Exit(1)
+	}
+
+	want := []string{".", "css", "css/style.css", "index.html", "js", "js/app.js"}
+	if fmt.Sprint(walked) != fmt.Sprint(want) {
+		fmt.Fprintf(os.Stderr, "fs.WalkDir paths = %v, want %v\n", walked, want)
+		os.Exit(1)
+	}
+}
+`